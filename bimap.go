@@ -1,7 +1,9 @@
 package atlas
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"sync"
 
 	"golang.org/x/exp/maps"
@@ -12,6 +14,21 @@ var (
 	ErrDuplicatedValue = errors.New("duplicated value")
 )
 
+// MergeConflictError reports every key and value collision found while merging a map into a
+// biMap, rather than stopping at the first one.
+type MergeConflictError[K comparable, V comparable] struct {
+	DuplicatedKeys   []K
+	DuplicatedValues []V
+}
+
+func (e *MergeConflictError[K, V]) Error() string {
+	return fmt.Sprintf(
+		"merge conflict: duplicated keys %v, duplicated values %v",
+		e.DuplicatedKeys,
+		e.DuplicatedValues,
+	)
+}
+
 type biMap[K comparable, V comparable] struct {
 	mu      sync.Mutex
 	mp      map[K]V
@@ -113,3 +130,131 @@ func (m *biMap[K, V]) ToMap() map[K]V {
 func (m *biMap[K, V]) ToMapInverse() map[V]K {
 	return maps.Clone(m.inverse)
 }
+
+// Merge merges other into m, returning a *MergeConflictError listing every colliding key and
+// value if any are found, including collisions between entries of other itself. On conflict, no
+// entries from other are applied.
+func (m *biMap[K, V]) Merge(other map[K]V) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var conflictKeys []K
+	var conflictValues []V
+
+	seenValues := make(map[V]struct{}, len(other))
+	for k, v := range other {
+		if _, ok := m.mp[k]; ok {
+			conflictKeys = append(conflictKeys, k)
+		}
+		if _, ok := m.inverse[v]; ok {
+			conflictValues = append(conflictValues, v)
+		}
+		if _, ok := seenValues[v]; ok {
+			conflictValues = append(conflictValues, v)
+		}
+		seenValues[v] = struct{}{}
+	}
+
+	if len(conflictKeys) > 0 || len(conflictValues) > 0 {
+		return &MergeConflictError[K, V]{DuplicatedKeys: conflictKeys, DuplicatedValues: conflictValues}
+	}
+
+	m.applyMergeLocked(other)
+
+	return nil
+}
+
+// MarshalJSON marshals the map like a plain map[K]V.
+func (m *biMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return json.Marshal(m.mp)
+}
+
+// validateMergeLocked checks whether mp can be merged into m without colliding with m's existing
+// entries or with itself. It must be called while holding m.mu.
+func (m *biMap[K, V]) validateMergeLocked(mp map[K]V) error {
+	seenValues := make(map[V]struct{}, len(mp))
+
+	for k, v := range mp {
+		if _, ok := m.mp[k]; ok {
+			return ErrDuplicatedKey
+		}
+		if _, ok := m.inverse[v]; ok {
+			return ErrDuplicatedValue
+		}
+		if _, ok := seenValues[v]; ok {
+			return ErrDuplicatedValue
+		}
+		seenValues[v] = struct{}{}
+	}
+
+	return nil
+}
+
+// applyMergeLocked merges mp into m. It must only be called after validateMergeLocked has
+// succeeded for the same mp, while still holding m.mu.
+func (m *biMap[K, V]) applyMergeLocked(mp map[K]V) {
+	if m.mp == nil {
+		m.mp = make(map[K]V)
+	}
+	if m.inverse == nil {
+		m.inverse = make(map[V]K)
+	}
+
+	for k, v := range mp {
+		m.mp[k] = v
+		m.inverse[v] = k
+	}
+}
+
+// UnmarshalJSON restores the map from a plain map[K]V. It fails with ErrDuplicatedKey or
+// ErrDuplicatedValue if the input contains a key or value already present in the map, or
+// duplicated within the input itself, leaving m untouched.
+func (m *biMap[K, V]) UnmarshalJSON(data []byte) error {
+	var mp map[K]V
+	if err := json.Unmarshal(data, &mp); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.validateMergeLocked(mp); err != nil {
+		return err
+	}
+
+	m.applyMergeLocked(mp)
+
+	return nil
+}
+
+// MarshalYAML marshals the map like a plain map[K]V.
+func (m *biMap[K, V]) MarshalYAML() (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.mp, nil
+}
+
+// UnmarshalYAML restores the map from a plain map[K]V. It fails with ErrDuplicatedKey or
+// ErrDuplicatedValue if the input contains a key or value already present in the map, or
+// duplicated within the input itself, leaving m untouched.
+func (m *biMap[K, V]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var mp map[K]V
+	if err := unmarshal(&mp); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.validateMergeLocked(mp); err != nil {
+		return err
+	}
+
+	m.applyMergeLocked(mp)
+
+	return nil
+}
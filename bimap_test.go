@@ -1,13 +1,20 @@
 package atlas
 
 import (
+	"encoding/json"
 	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
+type bimapTestStruct struct {
+	Name string
+	Age  int
+}
+
 func TestBiMapSet(t *testing.T) {
 	m := NewBiMap[string, string]()
 	assert.Empty(t, m.mp)
@@ -294,3 +301,139 @@ func TestBiMapToMapInverse(t *testing.T) {
 	gotMap["value4"] = "key4"
 	assert.NotEqual(t, m.inverse, gotMap)
 }
+
+func TestBiMapMerge(t *testing.T) {
+	t.Run("merges successfully when there's no collision", func(t *testing.T) {
+		m := NewBiMap[string, string]()
+		require.NoError(t, m.Set("key1", "value1"))
+
+		err := m.Merge(map[string]string{"key2": "value2", "key3": "value3"})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"key1": "value1", "key2": "value2", "key3": "value3"}, m.ToMap())
+	})
+
+	t.Run("returns every colliding key and value instead of stopping at the first", func(t *testing.T) {
+		m := NewBiMap[string, string]()
+		require.NoError(t, m.Set("key1", "value1"))
+		require.NoError(t, m.Set("key2", "value2"))
+
+		err := m.Merge(map[string]string{"key1": "new1", "other": "value2"})
+
+		var conflictErr *MergeConflictError[string, string]
+		require.ErrorAs(t, err, &conflictErr)
+		assert.ElementsMatch(t, []string{"key1"}, conflictErr.DuplicatedKeys)
+		assert.ElementsMatch(t, []string{"value2"}, conflictErr.DuplicatedValues)
+
+		// No changes should have been applied.
+		assert.Equal(t, map[string]string{"key1": "value1", "key2": "value2"}, m.ToMap())
+	})
+
+	t.Run("catches value collisions within the incoming batch itself", func(t *testing.T) {
+		m := NewBiMap[string, string]()
+
+		err := m.Merge(map[string]string{"a": "x", "b": "x"})
+
+		var conflictErr *MergeConflictError[string, string]
+		require.ErrorAs(t, err, &conflictErr)
+		assert.ElementsMatch(t, []string{"x", "x"}, conflictErr.DuplicatedValues)
+
+		// No changes should have been applied.
+		assert.Equal(t, map[string]string{}, m.ToMap())
+	})
+}
+
+func TestBiMapMarshalJSON(t *testing.T) {
+	m := NewBiMap[string, int]()
+	require.NoError(t, m.Set("key1", 1))
+	require.NoError(t, m.Set("key2", 2))
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+
+	var got map[string]int
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, got)
+}
+
+func TestBiMapUnmarshalJSON(t *testing.T) {
+	t.Run("round trips strings and ints", func(t *testing.T) {
+		strMap := NewBiMap[string, string]()
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":"value1","key2":"value2"}`), strMap))
+		assert.Equal(t, map[string]string{"key1": "value1", "key2": "value2"}, strMap.ToMap())
+		assert.Equal(t, map[string]string{"value1": "key1", "value2": "key2"}, strMap.ToMapInverse())
+
+		intMap := NewBiMap[string, int]()
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":1,"key2":2}`), intMap))
+		assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, intMap.ToMap())
+
+		structMap := NewBiMap[string, bimapTestStruct]()
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":{"Name":"Alice","Age":30}}`), structMap))
+		assert.Equal(t, map[string]bimapTestStruct{"key1": {Name: "Alice", Age: 30}}, structMap.ToMap())
+	})
+
+	t.Run("returns error when a key is duplicated", func(t *testing.T) {
+		m := NewBiMap[string, string]()
+		require.NoError(t, m.Set("key1", "value1"))
+
+		err := json.Unmarshal([]byte(`{"key1":"other"}`), m)
+		assert.EqualError(t, err, ErrDuplicatedKey.Error())
+	})
+
+	t.Run("returns error when a value is duplicated", func(t *testing.T) {
+		m := NewBiMap[string, string]()
+		require.NoError(t, m.Set("key1", "value1"))
+
+		err := json.Unmarshal([]byte(`{"other":"value1"}`), m)
+		assert.EqualError(t, err, ErrDuplicatedValue.Error())
+	})
+
+	t.Run("leaves the map untouched when only one of many entries collides", func(t *testing.T) {
+		m := NewBiMap[string, string]()
+		require.NoError(t, m.Set("key1", "value1"))
+
+		err := json.Unmarshal(
+			[]byte(`{"key1":"other","a":"1","b":"2","c":"3","d":"4","e":"5","f":"6","g":"7"}`),
+			m,
+		)
+		assert.EqualError(t, err, ErrDuplicatedKey.Error())
+		assert.Equal(t, map[string]string{"key1": "value1"}, m.ToMap())
+	})
+}
+
+func TestBiMapMarshalYAML(t *testing.T) {
+	m := NewBiMap[string, int]()
+	require.NoError(t, m.Set("key1", 1))
+	require.NoError(t, m.Set("key2", 2))
+
+	data, err := yaml.Marshal(m)
+	require.NoError(t, err)
+
+	var got map[string]int
+	require.NoError(t, yaml.Unmarshal(data, &got))
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, got)
+}
+
+func TestBiMapUnmarshalYAML(t *testing.T) {
+	t.Run("round trips strings and ints", func(t *testing.T) {
+		strMap := NewBiMap[string, string]()
+		require.NoError(t, yaml.Unmarshal([]byte("key1: value1\nkey2: value2\n"), strMap))
+		assert.Equal(t, map[string]string{"key1": "value1", "key2": "value2"}, strMap.ToMap())
+
+		intMap := NewBiMap[string, int]()
+		require.NoError(t, yaml.Unmarshal([]byte("key1: 1\nkey2: 2\n"), intMap))
+		assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, intMap.ToMap())
+
+		structMap := NewBiMap[string, bimapTestStruct]()
+		require.NoError(t, yaml.Unmarshal([]byte("key1:\n  name: Alice\n  age: 30\n"), structMap))
+		assert.Equal(t, map[string]bimapTestStruct{"key1": {Name: "Alice", Age: 30}}, structMap.ToMap())
+	})
+
+	t.Run("returns error when a key is duplicated", func(t *testing.T) {
+		m := NewBiMap[string, string]()
+		require.NoError(t, m.Set("key1", "value1"))
+
+		err := yaml.Unmarshal([]byte("key1: other\n"), m)
+		assert.EqualError(t, err, ErrDuplicatedKey.Error())
+	})
+}
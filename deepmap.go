@@ -0,0 +1,174 @@
+package atlas
+
+import (
+	"sync"
+
+	"golang.org/x/exp/maps"
+)
+
+// deepMap is a concurrency-safe map that supports recursive merging of nested map[string]any
+// values, mirroring Elastic's MapStr semantics.
+type deepMap[K comparable, V any] struct {
+	mu sync.Mutex
+	mp map[K]V
+}
+
+// NewDeepMap returns a new deepMap.
+func NewDeepMap[K comparable, V any]() *deepMap[K, V] {
+	return &deepMap[K, V]{mp: make(map[K]V)}
+}
+
+// Set sets the value for the given key, overwriting any existing value.
+func (m *deepMap[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mp[key] = value
+}
+
+// Has checks whether a key exists in the map.
+func (m *deepMap[K, V]) Has(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.mp[key]
+	return ok
+}
+
+// Get gets the value of the given key.
+func (m *deepMap[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.mp[key]
+	return value, ok
+}
+
+// Delete deletes the map key.
+func (m *deepMap[K, V]) Delete(key K) {
+	if !m.Has(key) {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.mp, key)
+}
+
+// Keys returns the map keys.
+func (m *deepMap[K, V]) Keys() []K {
+	return maps.Keys(m.mp)
+}
+
+// Values returns the map values.
+func (m *deepMap[K, V]) Values() []V {
+	return maps.Values(m.mp)
+}
+
+// Size returns the map size.
+func (m *deepMap[K, V]) Size() int {
+	return len(m.mp)
+}
+
+// ToMap returns a shallow clone of the original map.
+func (m *deepMap[K, V]) ToMap() map[K]V {
+	return maps.Clone(m.mp)
+}
+
+// Update overwrites the top-level entries of m with those from other.
+func (m *deepMap[K, V]) Update(other map[K]V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, v := range other {
+		m.mp[k] = v
+	}
+}
+
+// DeepUpdate recursively merges other into m. For a key present on both sides, if the existing
+// and incoming values both hold a map[string]any (or equivalent nested map), they're merged
+// recursively; otherwise the incoming value replaces the existing one wholesale. Slices are
+// always replaced, never concatenated.
+func (m *deepMap[K, V]) DeepUpdate(other map[K]V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, v := range other {
+		existing, ok := m.mp[k]
+		if !ok {
+			m.mp[k] = v
+			continue
+		}
+
+		m.mp[k] = deepMergeValue(existing, v)
+	}
+}
+
+// deepMergeValue merges incoming into existing when both sides resolve to a nested
+// map[string]any, falling back to replacing existing with incoming otherwise. The merged result
+// is rewrapped into existing's concrete container type (map[string]any, *frozenMap[string, any]
+// or *pathMap) before being returned, so merging two *pathMap values yields another *pathMap
+// instead of collapsing to a plain map.
+func deepMergeValue[V any](existing, incoming V) V {
+	existingMap, existingIsMap := asStringMap(existing)
+	incomingMap, incomingIsMap := asStringMap(incoming)
+
+	if !existingIsMap || !incomingIsMap {
+		return incoming
+	}
+
+	merged := make(map[string]any, len(existingMap))
+	for k, v := range existingMap {
+		merged[k] = v
+	}
+
+	for k, v := range incomingMap {
+		if ev, ok := merged[k]; ok {
+			merged[k] = deepMergeValue(ev, v)
+		} else {
+			merged[k] = v
+		}
+	}
+
+	if mergedV, ok := rewrapStringMap(existing, merged).(V); ok {
+		return mergedV
+	}
+
+	return incoming
+}
+
+// rewrapStringMap converts merged back into the same concrete shape as original, so DeepUpdate
+// preserves the original container type instead of always collapsing nested values to a plain
+// map[string]any.
+func rewrapStringMap(original any, merged map[string]any) any {
+	switch original.(type) {
+	case *frozenMap[string, any]:
+		return &frozenMap[string, any]{mp: merged}
+	case *pathMap:
+		return &pathMap{mp: merged}
+	default:
+		return merged
+	}
+}
+
+// asStringMap normalizes map[string]any, *frozenMap[string, any] and *pathMap into a plain
+// map[string]any for deep merging purposes.
+func asStringMap(v any) (map[string]any, bool) {
+	switch node := v.(type) {
+	case map[string]any:
+		return node, true
+	case *frozenMap[string, any]:
+		return node.ToMap(), true
+	case *pathMap:
+		node.mu.RLock()
+		defer node.mu.RUnlock()
+
+		cloned := make(map[string]any, len(node.mp))
+		for k, v := range node.mp {
+			cloned[k] = v
+		}
+
+		return cloned, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,200 @@
+package atlas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepMapSet(t *testing.T) {
+	m := NewDeepMap[string, string]()
+	assert.Empty(t, m.mp)
+
+	m.Set("key", "value")
+	assert.Contains(t, m.mp, "key")
+	assert.Equal(t, "value", m.mp["key"])
+
+	m.Set("key", "overwritten")
+	assert.Equal(t, "overwritten", m.mp["key"])
+}
+
+func TestDeepMapGet(t *testing.T) {
+	m := NewDeepMap[string, string]()
+
+	value, ok := m.Get("key")
+	assert.False(t, ok)
+	assert.Empty(t, value)
+
+	m.Set("key", "value")
+	value, ok = m.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+}
+
+func TestDeepMapHas(t *testing.T) {
+	m := NewDeepMap[string, string]()
+	assert.False(t, m.Has("key"))
+
+	m.Set("key", "value")
+	assert.True(t, m.Has("key"))
+}
+
+func TestDeepMapDelete(t *testing.T) {
+	m := NewDeepMap[string, string]()
+
+	// Nothing happens
+	m.Delete("key")
+
+	m.Set("key", "value")
+	assert.True(t, m.Has("key"))
+
+	m.Delete("key")
+	assert.False(t, m.Has("key"))
+}
+
+func TestDeepMapKeysAndValues(t *testing.T) {
+	m := NewDeepMap[string, string]()
+	m.Set("key1", "value1")
+	m.Set("key2", "value2")
+
+	assert.ElementsMatch(t, []string{"key1", "key2"}, m.Keys())
+	assert.ElementsMatch(t, []string{"value1", "value2"}, m.Values())
+}
+
+func TestDeepMapSize(t *testing.T) {
+	m := NewDeepMap[string, string]()
+	assert.Equal(t, 0, m.Size())
+
+	m.Set("key1", "value1")
+	assert.Equal(t, 1, m.Size())
+}
+
+func TestDeepMapToMap(t *testing.T) {
+	m := NewDeepMap[string, string]()
+	m.Set("key1", "value1")
+
+	gotMap := m.ToMap()
+	assert.Equal(t, m.mp, gotMap)
+
+	gotMap["key2"] = "value2"
+	assert.NotEqual(t, m.mp, gotMap)
+}
+
+func TestDeepMapUpdate(t *testing.T) {
+	m := NewDeepMap[string, string]()
+	m.Set("key1", "value1")
+
+	m.Update(map[string]string{"key1": "overwritten", "key2": "value2"})
+
+	assert.Equal(t, "overwritten", m.mp["key1"])
+	assert.Equal(t, "value2", m.mp["key2"])
+}
+
+func TestDeepMapDeepUpdate(t *testing.T) {
+	t.Run("merges 3+ levels of nesting and mixed value types", func(t *testing.T) {
+		m := NewDeepMap[string, any]()
+		m.Set("config", map[string]any{
+			"level1": map[string]any{
+				"level2": map[string]any{
+					"level3": "original",
+					"count":  1,
+				},
+				"kept": true,
+			},
+		})
+
+		m.DeepUpdate(map[string]any{
+			"config": map[string]any{
+				"level1": map[string]any{
+					"level2": map[string]any{
+						"level3": "updated",
+						"extra":  "new",
+					},
+				},
+			},
+		})
+
+		assert.Equal(t, map[string]any{
+			"level1": map[string]any{
+				"level2": map[string]any{
+					"level3": "updated",
+					"count":  1,
+					"extra":  "new",
+				},
+				"kept": true,
+			},
+		}, m.mp["config"])
+	})
+
+	t.Run("replaces slices wholesale instead of concatenating", func(t *testing.T) {
+		m := NewDeepMap[string, any]()
+		m.Set("key1", map[string]any{"list": []any{1, 2, 3}})
+
+		m.DeepUpdate(map[string]any{"key1": map[string]any{"list": []any{4}}})
+
+		assert.Equal(t, map[string]any{"list": []any{4}}, m.mp["key1"])
+	})
+
+	t.Run("merges a nested pathMap value recursively", func(t *testing.T) {
+		m := NewDeepMap[string, any]()
+
+		existing := NewPathMap()
+		require.NoError(t, existing.SetByPath("a", 1))
+		require.NoError(t, existing.SetByPath("nested.x", "1"))
+		m.Set("config", existing)
+
+		m.DeepUpdate(map[string]any{
+			"config": map[string]any{
+				"b":      2,
+				"nested": map[string]any{"y": "2"},
+			},
+		})
+
+		merged, ok := m.mp["config"].(*pathMap)
+		require.True(t, ok)
+		assert.Equal(t, map[string]any{
+			"a": 1,
+			"b": 2,
+			"nested": map[string]any{
+				"x": "1",
+				"y": "2",
+			},
+		}, merged.mp)
+	})
+
+	t.Run("adds new keys that didn't exist before", func(t *testing.T) {
+		m := NewDeepMap[string, any]()
+		m.Set("key1", "value1")
+
+		m.DeepUpdate(map[string]any{"key2": "value2"})
+
+		assert.Equal(t, "value1", m.mp["key1"])
+		assert.Equal(t, "value2", m.mp["key2"])
+	})
+
+	t.Run("merges recursively when V is a concrete map-like type, not just any", func(t *testing.T) {
+		m := NewDeepMap[string, *pathMap]()
+
+		existing := NewPathMap()
+		require.NoError(t, existing.SetByPath("a", 1))
+		require.NoError(t, existing.SetByPath("nested.x", "1"))
+		m.Set("config", existing)
+
+		incoming := NewPathMap()
+		require.NoError(t, incoming.SetByPath("b", 2))
+		require.NoError(t, incoming.SetByPath("nested.y", "2"))
+		m.DeepUpdate(map[string]*pathMap{"config": incoming})
+
+		merged, ok := m.Get("config")
+		require.True(t, ok)
+		assert.Equal(t, map[string]any{
+			"a": 1,
+			"b": 2,
+			"nested": map[string]any{
+				"x": "1",
+				"y": "2",
+			},
+		}, merged.mp)
+	})
+}
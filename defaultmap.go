@@ -0,0 +1,479 @@
+package atlas
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/maps"
+)
+
+// EvictionPolicy selects which entry a size-bounded defaultMap evicts once it's full.
+type EvictionPolicy int
+
+const (
+	// EvictionLRU evicts the least recently used entry.
+	EvictionLRU EvictionPolicy = iota
+	// EvictionLFU evicts the least frequently used entry.
+	EvictionLFU
+)
+
+// DefaultMapStats reports cache behavior for a defaultMap.
+type DefaultMapStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// DefaultMapOption configures a defaultMap created via NewDefaultMapWithOptions.
+type DefaultMapOption func(*defaultMapConfig)
+
+type defaultMapConfig struct {
+	ttl             time.Duration
+	maxSize         int
+	eviction        EvictionPolicy
+	janitorInterval time.Duration
+}
+
+// WithTTL makes entries expire d after they were last set or touched. A Get on an expired entry
+// is treated as a miss and regenerates the value via genFunc.
+func WithTTL(d time.Duration) DefaultMapOption {
+	return func(c *defaultMapConfig) {
+		c.ttl = d
+	}
+}
+
+// WithMaxSize bounds the map at n entries, evicting according to policy once it's full.
+func WithMaxSize(n int, policy EvictionPolicy) DefaultMapOption {
+	return func(c *defaultMapConfig) {
+		c.maxSize = n
+		c.eviction = policy
+	}
+}
+
+// WithJanitorInterval sets how often the background janitor started via Start sweeps expired
+// entries. Only entries with a TTL, from WithTTL or SetWithTTL, are ever swept.
+func WithJanitorInterval(d time.Duration) DefaultMapOption {
+	return func(c *defaultMapConfig) {
+		c.janitorInterval = d
+	}
+}
+
+// defaultMapMeta tracks the TTL/eviction bookkeeping for a single entry, kept separate from mp so
+// mp remains a plain map[K]V.
+type defaultMapMeta struct {
+	expiresAt time.Time
+	elem      *list.Element
+	freq      uint64
+}
+
+type defaultMap[K comparable, V any] struct {
+	mu      sync.Mutex
+	mp      map[K]V
+	genFunc func() (V, error)
+
+	ttl      time.Duration
+	maxSize  int
+	eviction EvictionPolicy
+
+	meta  map[K]*defaultMapMeta
+	order *list.List // list of K, front = most recently used
+
+	stats DefaultMapStats
+
+	janitorInterval time.Duration
+	janitorCancel   context.CancelFunc
+	janitorWG       sync.WaitGroup
+}
+
+// NewDefaultMap returns a new map that generates and stores a default value via genFunc whenever
+// Get is called for a key that hasn't been set yet. It panics if genFunc is nil.
+func NewDefaultMap[K comparable, V any](genFunc func() (V, error)) *defaultMap[K, V] {
+	return NewDefaultMapWithOptions[K, V](genFunc)
+}
+
+// NewDefaultMapWithOptions is like NewDefaultMap, additionally accepting options such as WithTTL
+// and WithMaxSize to bound the map's lifetime and size.
+func NewDefaultMapWithOptions[K comparable, V any](genFunc func() (V, error), opts ...DefaultMapOption) *defaultMap[K, V] {
+	if genFunc == nil {
+		panic("atlas: genFunc cannot be nil")
+	}
+
+	var cfg defaultMapConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &defaultMap[K, V]{
+		mp:              make(map[K]V),
+		genFunc:         genFunc,
+		ttl:             cfg.ttl,
+		maxSize:         cfg.maxSize,
+		eviction:        cfg.eviction,
+		meta:            make(map[K]*defaultMapMeta),
+		order:           list.New(),
+		janitorInterval: cfg.janitorInterval,
+	}
+}
+
+// Set sets the value for the given key, using the map's default TTL (if any).
+func (m *defaultMap[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.setLocked(key, value, m.ttl)
+}
+
+// SetWithTTL sets the value for the given key with a per-entry TTL override.
+func (m *defaultMap[K, V]) SetWithTTL(key K, value V, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.setLocked(key, value, d)
+}
+
+func (m *defaultMap[K, V]) setLocked(key K, value V, ttl time.Duration) {
+	m.mp[key] = value
+	m.touchMetaLocked(key, ttl)
+	m.evictIfNeededLocked()
+}
+
+// Touch resets the key's TTL to the map's default, as if it had just been set. It's a no-op if
+// the key isn't present.
+func (m *defaultMap[K, V]) Touch(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.mp[key]; !ok {
+		return
+	}
+
+	m.touchMetaLocked(key, m.ttl)
+}
+
+func (m *defaultMap[K, V]) touchMetaLocked(key K, ttl time.Duration) {
+	meta, ok := m.meta[key]
+	if !ok {
+		meta = &defaultMapMeta{elem: m.order.PushFront(key)}
+		m.meta[key] = meta
+	} else {
+		m.order.MoveToFront(meta.elem)
+	}
+
+	meta.freq++
+
+	if ttl > 0 {
+		meta.expiresAt = time.Now().Add(ttl)
+	} else {
+		meta.expiresAt = time.Time{}
+	}
+}
+
+// expiredLocked reports whether key is present but past its TTL.
+func (m *defaultMap[K, V]) expiredLocked(key K) bool {
+	meta, ok := m.meta[key]
+	if !ok {
+		return false
+	}
+
+	return !meta.expiresAt.IsZero() && time.Now().After(meta.expiresAt)
+}
+
+func (m *defaultMap[K, V]) removeLocked(key K) {
+	delete(m.mp, key)
+
+	if meta, ok := m.meta[key]; ok {
+		m.order.Remove(meta.elem)
+		delete(m.meta, key)
+	}
+}
+
+// evictIfNeededLocked evicts entries according to the configured policy until the map is back
+// within maxSize. It's a no-op when maxSize is unset.
+func (m *defaultMap[K, V]) evictIfNeededLocked() {
+	if m.maxSize <= 0 {
+		return
+	}
+
+	for len(m.mp) > m.maxSize {
+		victim, ok := m.victimLocked()
+		if !ok {
+			return
+		}
+
+		m.removeLocked(victim)
+		m.stats.Evictions++
+	}
+}
+
+// victimLocked picks the entry to evict. For EvictionLRU it's simply the back of order (the
+// least recently used key). For EvictionLFU it's the least frequently used key, breaking ties by
+// recency (the least recently used among those tied for lowest frequency).
+func (m *defaultMap[K, V]) victimLocked() (K, bool) {
+	var zero K
+
+	back := m.order.Back()
+	if back == nil {
+		return zero, false
+	}
+
+	if m.eviction != EvictionLFU {
+		return back.Value.(K), true
+	}
+
+	minFreq := m.meta[back.Value.(K)].freq
+	for e := back; e != nil; e = e.Prev() {
+		if freq := m.meta[e.Value.(K)].freq; freq < minFreq {
+			minFreq = freq
+		}
+	}
+
+	for e := m.order.Back(); e != nil; e = e.Prev() {
+		key := e.Value.(K)
+		if m.meta[key].freq == minFreq {
+			return key, true
+		}
+	}
+
+	return zero, false
+}
+
+// Has checks whether a key exists in the map and hasn't expired.
+func (m *defaultMap[K, V]) Has(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.mp[key]; !ok {
+		return false
+	}
+
+	return !m.expiredLocked(key)
+}
+
+// Get gets the value of the given key. If the key isn't set, or is set but has expired, a default
+// value is generated via genFunc, stored under the key (refreshing its TTL) and returned.
+func (m *defaultMap[K, V]) Get(key K) (V, error) {
+	m.mu.Lock()
+
+	value, ok := m.mp[key]
+	if ok && m.expiredLocked(key) {
+		m.removeLocked(key)
+		ok = false
+	}
+
+	if ok {
+		// A hit counts as a use for eviction but leaves the entry's expiry as it was set.
+		meta := m.meta[key]
+		m.order.MoveToFront(meta.elem)
+		meta.freq++
+		m.stats.Hits++
+		m.mu.Unlock()
+
+		return value, nil
+	}
+
+	m.stats.Misses++
+	m.mu.Unlock()
+
+	value, err := m.genFunc()
+	if err != nil {
+		var zero V
+		return zero, fmt.Errorf("getting value from genFunc: %w", err)
+	}
+
+	m.Set(key, value)
+
+	return value, nil
+}
+
+// Delete deletes the map key.
+func (m *defaultMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.mp[key]; !ok {
+		return
+	}
+
+	m.removeLocked(key)
+}
+
+// Keys returns the map keys, including any entries pending expiration that haven't been swept or
+// accessed yet.
+func (m *defaultMap[K, V]) Keys() []K {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return maps.Keys(m.mp)
+}
+
+// Values returns the map values, including any entries pending expiration that haven't been swept
+// or accessed yet.
+func (m *defaultMap[K, V]) Values() []V {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return maps.Values(m.mp)
+}
+
+// Size returns the map size, including any entries pending expiration that haven't been swept or
+// accessed yet.
+func (m *defaultMap[K, V]) Size() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.mp)
+}
+
+// ToMap returns a shallow clone of the original map.
+func (m *defaultMap[K, V]) ToMap() map[K]V {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return maps.Clone(m.mp)
+}
+
+// Stats returns a snapshot of the map's hit/miss/eviction counters.
+func (m *defaultMap[K, V]) Stats() DefaultMapStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.stats
+}
+
+// Start launches a background janitor goroutine that periodically sweeps expired entries, at the
+// interval set via WithJanitorInterval (defaulting to one minute). It's a no-op if the janitor is
+// already running; stop it with Close.
+func (m *defaultMap[K, V]) Start(ctx context.Context) {
+	m.mu.Lock()
+	if m.janitorCancel != nil {
+		m.mu.Unlock()
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	m.janitorCancel = cancel
+
+	interval := m.janitorInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	m.mu.Unlock()
+
+	m.janitorWG.Add(1)
+	go func() {
+		defer m.janitorWG.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.sweepExpired()
+			}
+		}
+	}()
+}
+
+// sweepExpired removes every entry that's currently past its TTL.
+func (m *defaultMap[K, V]) sweepExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.meta {
+		if m.expiredLocked(key) {
+			m.removeLocked(key)
+		}
+	}
+}
+
+// Close stops the background janitor started via Start, if any, and waits for it to exit. It's
+// safe to call Close more than once.
+func (m *defaultMap[K, V]) Close() error {
+	m.mu.Lock()
+	cancel := m.janitorCancel
+	m.janitorCancel = nil
+	m.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+
+	m.janitorWG.Wait()
+
+	return nil
+}
+
+// MarshalJSON marshals the concrete entries stored in the map. The genFunc cannot be serialized,
+// so only keys that were explicitly set or already generated are included.
+func (m *defaultMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return json.Marshal(m.mp)
+}
+
+// UnmarshalJSON restores the concrete entries into the map. The genFunc is left untouched, so it
+// must be set separately (e.g. via NewDefaultMap) before unmarshaling.
+func (m *defaultMap[K, V]) UnmarshalJSON(data []byte) error {
+	var mp map[K]V
+	if err := json.Unmarshal(data, &mp); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.mp == nil {
+		m.mp = make(map[K]V)
+	}
+
+	for k, v := range mp {
+		m.mp[k] = v
+		m.touchMetaLocked(k, m.ttl)
+	}
+
+	m.evictIfNeededLocked()
+
+	return nil
+}
+
+// MarshalYAML marshals the concrete entries stored in the map. The genFunc cannot be serialized,
+// so only keys that were explicitly set or already generated are included.
+func (m *defaultMap[K, V]) MarshalYAML() (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.mp, nil
+}
+
+// UnmarshalYAML restores the concrete entries into the map. The genFunc is left untouched, so it
+// must be set separately (e.g. via NewDefaultMap) before unmarshaling.
+func (m *defaultMap[K, V]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var mp map[K]V
+	if err := unmarshal(&mp); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.mp == nil {
+		m.mp = make(map[K]V)
+	}
+
+	for k, v := range mp {
+		m.mp[k] = v
+		m.touchMetaLocked(k, m.ttl)
+	}
+
+	m.evictIfNeededLocked()
+
+	return nil
+}
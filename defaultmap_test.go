@@ -1,17 +1,26 @@
 package atlas
 
 import (
+	"context"
 	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
+type defaultMapTestStruct struct {
+	Name string
+	Age  int
+}
+
 var letterRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
 
 func randStringRunes(t *testing.T, size int) (string, error) {
@@ -248,3 +257,256 @@ func TestDefaultMapToMap(t *testing.T) {
 	gotMap["key4"] = "value4"
 	assert.NotEqual(t, m.mp, gotMap)
 }
+
+func TestDefaultMapMarshalJSON(t *testing.T) {
+	genFunc := func() (int, error) { return 0, nil }
+	m := NewDefaultMap[string, int](genFunc)
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+
+	var got map[string]int
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, got)
+}
+
+func TestDefaultMapUnmarshalJSON(t *testing.T) {
+	t.Run("restores concrete entries and keeps genFunc working for the rest", func(t *testing.T) {
+		genFunc := func() (string, error) { return "default", nil }
+		m := NewDefaultMap[string, string](genFunc)
+
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":"value1","key2":"value2"}`), m))
+
+		value, err := m.Get("key1")
+		require.NoError(t, err)
+		assert.Equal(t, "value1", value)
+
+		value, err = m.Get("missing-key")
+		require.NoError(t, err)
+		assert.Equal(t, "default", value)
+	})
+
+	t.Run("evicts down to maxSize after restoring more entries than it allows", func(t *testing.T) {
+		genFunc := func() (string, error) { return "default", nil }
+		m := NewDefaultMapWithOptions[string, string](genFunc, WithMaxSize(2, EvictionLRU))
+
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":"value1","key2":"value2","key3":"value3"}`), m))
+
+		assert.Equal(t, 2, m.Size())
+	})
+
+	t.Run("round trips ints and structs", func(t *testing.T) {
+		intMap := NewDefaultMap[string, int](func() (int, error) { return 0, nil })
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":1,"key2":2}`), intMap))
+		assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, intMap.ToMap())
+
+		structMap := NewDefaultMap[string, defaultMapTestStruct](func() (defaultMapTestStruct, error) {
+			return defaultMapTestStruct{}, nil
+		})
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":{"Name":"Alice","Age":30}}`), structMap))
+		assert.Equal(t, map[string]defaultMapTestStruct{"key1": {Name: "Alice", Age: 30}}, structMap.ToMap())
+	})
+}
+
+func TestDefaultMapMarshalYAML(t *testing.T) {
+	genFunc := func() (int, error) { return 0, nil }
+	m := NewDefaultMap[string, int](genFunc)
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+
+	data, err := yaml.Marshal(m)
+	require.NoError(t, err)
+
+	var got map[string]int
+	require.NoError(t, yaml.Unmarshal(data, &got))
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, got)
+}
+
+func TestDefaultMapUnmarshalYAML(t *testing.T) {
+	t.Run("restores concrete entries and keeps genFunc working for the rest", func(t *testing.T) {
+		genFunc := func() (string, error) { return "default", nil }
+		m := NewDefaultMap[string, string](genFunc)
+
+		require.NoError(t, yaml.Unmarshal([]byte("key1: value1\nkey2: value2\n"), m))
+
+		value, err := m.Get("key1")
+		require.NoError(t, err)
+		assert.Equal(t, "value1", value)
+
+		value, err = m.Get("missing-key")
+		require.NoError(t, err)
+		assert.Equal(t, "default", value)
+	})
+
+	t.Run("evicts down to maxSize after restoring more entries than it allows", func(t *testing.T) {
+		genFunc := func() (string, error) { return "default", nil }
+		m := NewDefaultMapWithOptions[string, string](genFunc, WithMaxSize(2, EvictionLRU))
+
+		require.NoError(t, yaml.Unmarshal([]byte("key1: value1\nkey2: value2\nkey3: value3\n"), m))
+
+		assert.Equal(t, 2, m.Size())
+	})
+}
+
+func TestDefaultMapTTL(t *testing.T) {
+	genFunc := func() (string, error) { return "default", nil }
+
+	t.Run("regenerates via genFunc once the TTL has elapsed", func(t *testing.T) {
+		m := NewDefaultMapWithOptions[string, string](genFunc, WithTTL(time.Millisecond))
+		m.Set("key", "value")
+
+		value, err := m.Get("key")
+		require.NoError(t, err)
+		assert.Equal(t, "value", value)
+
+		time.Sleep(5 * time.Millisecond)
+
+		value, err = m.Get("key")
+		require.NoError(t, err)
+		assert.Equal(t, "default", value)
+	})
+
+	t.Run("Has reports false for an expired key", func(t *testing.T) {
+		m := NewDefaultMapWithOptions[string, string](genFunc, WithTTL(time.Millisecond))
+		m.Set("key", "value")
+
+		time.Sleep(5 * time.Millisecond)
+
+		assert.False(t, m.Has("key"))
+	})
+
+	t.Run("Touch resets the TTL", func(t *testing.T) {
+		m := NewDefaultMapWithOptions[string, string](genFunc, WithTTL(10*time.Millisecond))
+		m.Set("key", "value")
+
+		time.Sleep(6 * time.Millisecond)
+		m.Touch("key")
+		time.Sleep(6 * time.Millisecond)
+
+		assert.True(t, m.Has("key"))
+	})
+
+	t.Run("SetWithTTL overrides the default TTL for a single entry", func(t *testing.T) {
+		m := NewDefaultMapWithOptions[string, string](genFunc, WithTTL(time.Hour))
+		m.SetWithTTL("key", "value", time.Millisecond)
+
+		time.Sleep(5 * time.Millisecond)
+
+		assert.False(t, m.Has("key"))
+	})
+
+	t.Run("janitor sweeps expired entries in the background", func(t *testing.T) {
+		m := NewDefaultMapWithOptions[string, string](
+			genFunc,
+			WithTTL(time.Millisecond),
+			WithJanitorInterval(2*time.Millisecond),
+		)
+		m.Set("key", "value")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		m.Start(ctx)
+		defer func() { require.NoError(t, m.Close()) }()
+
+		assert.Eventually(t, func() bool {
+			return m.Size() == 0
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+
+	t.Run("Close is idempotent", func(t *testing.T) {
+		m := NewDefaultMapWithOptions[string, string](genFunc, WithTTL(time.Minute))
+		m.Start(context.Background())
+
+		require.NoError(t, m.Close())
+		require.NoError(t, m.Close())
+	})
+}
+
+func TestDefaultMapEviction(t *testing.T) {
+	genFunc := func() (string, error) { return "default", nil }
+
+	t.Run("evicts the least recently used entry once over maxSize", func(t *testing.T) {
+		m := NewDefaultMapWithOptions[string, string](genFunc, WithMaxSize(2, EvictionLRU))
+		m.Set("key1", "value1")
+		m.Set("key2", "value2")
+
+		// Touch key1 so it's no longer the least recently used entry.
+		_, err := m.Get("key1")
+		require.NoError(t, err)
+
+		m.Set("key3", "value3")
+
+		assert.True(t, m.Has("key1"))
+		assert.False(t, m.Has("key2"))
+		assert.True(t, m.Has("key3"))
+		assert.Equal(t, uint64(1), m.Stats().Evictions)
+	})
+
+	t.Run("evicts the least frequently used entry once over maxSize", func(t *testing.T) {
+		m := NewDefaultMapWithOptions[string, string](genFunc, WithMaxSize(2, EvictionLFU))
+		m.Set("key1", "value1")
+		m.Set("key2", "value2")
+
+		_, err := m.Get("key1")
+		require.NoError(t, err)
+		_, err = m.Get("key1")
+		require.NoError(t, err)
+
+		m.Set("key3", "value3")
+
+		assert.True(t, m.Has("key1"))
+		assert.False(t, m.Has("key2"))
+		assert.True(t, m.Has("key3"))
+	})
+}
+
+func TestDefaultMapStats(t *testing.T) {
+	genFunc := func() (string, error) { return "default", nil }
+	m := NewDefaultMap[string, string](genFunc)
+
+	m.Set("key1", "value1")
+
+	_, err := m.Get("key1")
+	require.NoError(t, err)
+
+	_, err = m.Get("missing")
+	require.NoError(t, err)
+
+	stats := m.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(0), stats.Evictions)
+}
+
+func TestDefaultMapTTLRaceCondition(t *testing.T) {
+	genFunc := func() (string, error) { return "default", nil }
+	m := NewDefaultMapWithOptions[string, string](genFunc, WithTTL(time.Millisecond), WithMaxSize(10, EvictionLRU))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Start(ctx)
+	defer func() { require.NoError(t, m.Close()) }()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			m.Set("key", "value")
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_, err := m.Get("key")
+			require.NoError(t, err)
+		}
+	}()
+
+	wg.Wait()
+}
@@ -1,6 +1,7 @@
 package atlas
 
 import (
+	"encoding/json"
 	"errors"
 	"sync"
 
@@ -81,3 +82,113 @@ func (m *frozenMap[K, V]) Size() int {
 func (m *frozenMap[K, V]) ToMap() map[K]V {
 	return maps.Clone(m.mp)
 }
+
+// Update overwrites the top-level entries of m with those from other, bypassing the
+// ErrKeyAlreadySet check performed by Set.
+func (m *frozenMap[K, V]) Update(other map[K]V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, v := range other {
+		m.mp[k] = v
+	}
+}
+
+// DeepUpdate recursively merges other into m. For a key present on both sides, if the existing
+// and incoming values both hold a map[string]any (or equivalent nested map), they're merged
+// recursively; otherwise the incoming value replaces the existing one wholesale, bypassing the
+// ErrKeyAlreadySet check performed by Set. Slices are always replaced, never concatenated.
+func (m *frozenMap[K, V]) DeepUpdate(other map[K]V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, v := range other {
+		existing, ok := m.mp[k]
+		if !ok {
+			m.mp[k] = v
+			continue
+		}
+
+		m.mp[k] = deepMergeValue(existing, v)
+	}
+}
+
+// MarshalJSON marshals the map like a plain map[K]V.
+func (m *frozenMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return json.Marshal(m.mp)
+}
+
+// validateUnmarshalLocked checks that none of mp's keys are already set in m. It must be called
+// while holding m.mu.
+func (m *frozenMap[K, V]) validateUnmarshalLocked(mp map[K]V) error {
+	for k := range mp {
+		if _, ok := m.mp[k]; ok {
+			return ErrKeyAlreadySet
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON restores the map from a plain map[K]V. Unmarshaling into a map that already
+// contains one of the incoming keys fails with ErrKeyAlreadySet, leaving m untouched.
+func (m *frozenMap[K, V]) UnmarshalJSON(data []byte) error {
+	var mp map[K]V
+	if err := json.Unmarshal(data, &mp); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.validateUnmarshalLocked(mp); err != nil {
+		return err
+	}
+
+	if m.mp == nil {
+		m.mp = make(map[K]V)
+	}
+
+	for k, v := range mp {
+		m.mp[k] = v
+	}
+
+	return nil
+}
+
+// MarshalYAML marshals the map like a plain map[K]V.
+func (m *frozenMap[K, V]) MarshalYAML() (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.mp, nil
+}
+
+// UnmarshalYAML restores the map from a plain map[K]V. Unmarshaling into a map that already
+// contains one of the incoming keys fails with ErrKeyAlreadySet, leaving m untouched.
+func (m *frozenMap[K, V]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var mp map[K]V
+	if err := unmarshal(&mp); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.validateUnmarshalLocked(mp); err != nil {
+		return err
+	}
+
+	if m.mp == nil {
+		m.mp = make(map[K]V)
+	}
+
+	for k, v := range mp {
+		m.mp[k] = v
+	}
+
+	return nil
+}
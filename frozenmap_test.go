@@ -1,13 +1,20 @@
 package atlas
 
 import (
+	"encoding/json"
 	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
+type frozenMapTestStruct struct {
+	Name string
+	Age  int
+}
+
 func TestFrozenMapSet(t *testing.T) {
 	m := NewFrozenMap[string, string]()
 	assert.Empty(t, m.mp)
@@ -193,3 +200,156 @@ func TestFrozenMapToMap(t *testing.T) {
 	gotMap["key4"] = "value4"
 	assert.NotEqual(t, m.mp, gotMap)
 }
+
+func TestFrozenMapUpdate(t *testing.T) {
+	m := NewFrozenMap[string, string]()
+	require.NoError(t, m.Set("key1", "value1"))
+
+	m.Update(map[string]string{"key1": "overwritten", "key2": "value2"})
+
+	assert.Equal(t, "overwritten", m.mp["key1"])
+	assert.Equal(t, "value2", m.mp["key2"])
+}
+
+func TestFrozenMapDeepUpdate(t *testing.T) {
+	t.Run("merges nested maps recursively", func(t *testing.T) {
+		m := NewFrozenMap[string, any]()
+		require.NoError(t, m.Set("config", map[string]any{
+			"a": 1,
+			"nested": map[string]any{
+				"x": "1",
+				"y": "2",
+			},
+		}))
+
+		m.DeepUpdate(map[string]any{
+			"config": map[string]any{
+				"b": 2,
+				"nested": map[string]any{
+					"y": "overwritten",
+					"z": "3",
+				},
+			},
+		})
+
+		assert.Equal(t, map[string]any{
+			"a": 1,
+			"b": 2,
+			"nested": map[string]any{
+				"x": "1",
+				"y": "overwritten",
+				"z": "3",
+			},
+		}, m.mp["config"])
+	})
+
+	t.Run("replaces slices wholesale instead of concatenating", func(t *testing.T) {
+		m := NewFrozenMap[string, any]()
+		require.NoError(t, m.Set("config", map[string]any{
+			"list": []any{1, 2, 3},
+		}))
+
+		m.DeepUpdate(map[string]any{
+			"config": map[string]any{
+				"list": []any{4},
+			},
+		})
+
+		assert.Equal(t, map[string]any{"list": []any{4}}, m.mp["config"])
+	})
+
+	t.Run("replaces non-map values wholesale", func(t *testing.T) {
+		m := NewFrozenMap[string, any]()
+		require.NoError(t, m.Set("key1", "value1"))
+
+		m.DeepUpdate(map[string]any{"key1": "value2"})
+
+		assert.Equal(t, "value2", m.mp["key1"])
+	})
+}
+
+func TestFrozenMapMarshalJSON(t *testing.T) {
+	m := NewFrozenMap[string, int]()
+	require.NoError(t, m.Set("key1", 1))
+	require.NoError(t, m.Set("key2", 2))
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+
+	var got map[string]int
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, got)
+}
+
+func TestFrozenMapUnmarshalJSON(t *testing.T) {
+	t.Run("round trips strings, ints and structs", func(t *testing.T) {
+		strMap := NewFrozenMap[string, string]()
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":"value1","key2":"value2"}`), strMap))
+		assert.Equal(t, map[string]string{"key1": "value1", "key2": "value2"}, strMap.ToMap())
+
+		intMap := NewFrozenMap[string, int]()
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":1,"key2":2}`), intMap))
+		assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, intMap.ToMap())
+
+		structMap := NewFrozenMap[string, frozenMapTestStruct]()
+		require.NoError(t, json.Unmarshal([]byte(`{"key1":{"Name":"Alice","Age":30}}`), structMap))
+		assert.Equal(t, map[string]frozenMapTestStruct{"key1": {Name: "Alice", Age: 30}}, structMap.ToMap())
+	})
+
+	t.Run("returns error when a key is already set", func(t *testing.T) {
+		m := NewFrozenMap[string, string]()
+		require.NoError(t, m.Set("key1", "value1"))
+
+		err := json.Unmarshal([]byte(`{"key1":"other"}`), m)
+		assert.EqualError(t, err, ErrKeyAlreadySet.Error())
+	})
+
+	t.Run("leaves the map untouched when only one of many entries collides", func(t *testing.T) {
+		m := NewFrozenMap[string, string]()
+		require.NoError(t, m.Set("key1", "value1"))
+
+		err := json.Unmarshal(
+			[]byte(`{"key1":"other","a":"1","b":"2","c":"3","d":"4","e":"5","f":"6","g":"7"}`),
+			m,
+		)
+		assert.EqualError(t, err, ErrKeyAlreadySet.Error())
+		assert.Equal(t, map[string]string{"key1": "value1"}, m.ToMap())
+	})
+}
+
+func TestFrozenMapMarshalYAML(t *testing.T) {
+	m := NewFrozenMap[string, int]()
+	require.NoError(t, m.Set("key1", 1))
+	require.NoError(t, m.Set("key2", 2))
+
+	data, err := yaml.Marshal(m)
+	require.NoError(t, err)
+
+	var got map[string]int
+	require.NoError(t, yaml.Unmarshal(data, &got))
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, got)
+}
+
+func TestFrozenMapUnmarshalYAML(t *testing.T) {
+	t.Run("round trips strings, ints and structs", func(t *testing.T) {
+		strMap := NewFrozenMap[string, string]()
+		require.NoError(t, yaml.Unmarshal([]byte("key1: value1\nkey2: value2\n"), strMap))
+		assert.Equal(t, map[string]string{"key1": "value1", "key2": "value2"}, strMap.ToMap())
+
+		intMap := NewFrozenMap[string, int]()
+		require.NoError(t, yaml.Unmarshal([]byte("key1: 1\nkey2: 2\n"), intMap))
+		assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, intMap.ToMap())
+
+		structMap := NewFrozenMap[string, frozenMapTestStruct]()
+		require.NoError(t, yaml.Unmarshal([]byte("key1:\n  name: Alice\n  age: 30\n"), structMap))
+		assert.Equal(t, map[string]frozenMapTestStruct{"key1": {Name: "Alice", Age: 30}}, structMap.ToMap())
+	})
+
+	t.Run("returns error when a key is already set", func(t *testing.T) {
+		m := NewFrozenMap[string, string]()
+		require.NoError(t, m.Set("key1", "value1"))
+
+		err := yaml.Unmarshal([]byte("key1: other\n"), m)
+		assert.EqualError(t, err, ErrKeyAlreadySet.Error())
+	})
+}
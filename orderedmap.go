@@ -0,0 +1,230 @@
+package atlas
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"sync"
+)
+
+type orderedMapEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// orderedMap is a concurrency-safe map that preserves insertion order, backed by a hash map plus
+// a doubly-linked list of key nodes so Set/Delete remain O(1).
+type orderedMap[K comparable, V any] struct {
+	mu       sync.RWMutex
+	elements map[K]*list.Element
+	order    *list.List // list of *orderedMapEntry[K, V], front = oldest
+}
+
+// NewOrderedMap returns a new map that preserves insertion order.
+func NewOrderedMap[K comparable, V any]() *orderedMap[K, V] {
+	return &orderedMap[K, V]{
+		elements: make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Set sets the value for the given key. Setting an existing key updates its value in place
+// without changing its position; use MoveToBack for MRU semantics.
+func (m *orderedMap[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.elements[key]; ok {
+		elem.Value.(*orderedMapEntry[K, V]).value = value
+		return
+	}
+
+	elem := m.order.PushBack(&orderedMapEntry[K, V]{key: key, value: value})
+	m.elements[key] = elem
+}
+
+// Has checks whether a key exists in the map.
+func (m *orderedMap[K, V]) Has(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.elements[key]
+
+	return ok
+}
+
+// Get gets the value of the given key.
+func (m *orderedMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	elem, ok := m.elements[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	return elem.Value.(*orderedMapEntry[K, V]).value, true
+}
+
+// Delete deletes the map key.
+func (m *orderedMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.elements[key]
+	if !ok {
+		return
+	}
+
+	m.order.Remove(elem)
+	delete(m.elements, key)
+}
+
+// MoveToBack moves an existing key to the back of the insertion order, as if it had just been
+// set. It's a no-op if the key isn't present.
+func (m *orderedMap[K, V]) MoveToBack(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.elements[key]
+	if !ok {
+		return
+	}
+
+	m.order.MoveToBack(elem)
+}
+
+// Keys returns the map keys in insertion order.
+func (m *orderedMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]K, 0, m.order.Len())
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(*orderedMapEntry[K, V]).key)
+	}
+
+	return keys
+}
+
+// Values returns the map values in insertion order.
+func (m *orderedMap[K, V]) Values() []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	values := make([]V, 0, m.order.Len())
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		values = append(values, e.Value.(*orderedMapEntry[K, V]).value)
+	}
+
+	return values
+}
+
+// Range calls fn for every key-value pair in insertion order, stopping early if fn returns false.
+func (m *orderedMap[K, V]) Range(fn func(K, V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*orderedMapEntry[K, V])
+		if !fn(entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+// GetPair returns the key-value pair at the given position in insertion order, also returning
+// whether index was in range.
+func (m *orderedMap[K, V]) GetPair(index int) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if index < 0 || index >= m.order.Len() {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+
+	e := m.order.Front()
+	for i := 0; i < index; i++ {
+		e = e.Next()
+	}
+
+	entry := e.Value.(*orderedMapEntry[K, V])
+
+	return entry.key, entry.value, true
+}
+
+// Size returns the map size.
+func (m *orderedMap[K, V]) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.order.Len()
+}
+
+// ToMap returns a plain map[K]V clone of the original map. Insertion order is lost, since a Go
+// map can't preserve it.
+func (m *orderedMap[K, V]) ToMap() map[K]V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make(map[K]V, m.order.Len())
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*orderedMapEntry[K, V])
+		out[entry.key] = entry.value
+	}
+
+	return out
+}
+
+// MarshalJSON marshals the map as a JSON object with keys in insertion order.
+func (m *orderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*orderedMapEntry[K, V])
+
+		keyBytes, err := json.Marshal(entry.key)
+		if err != nil {
+			return nil, err
+		}
+
+		keyBytes, err = jsonObjectKey(keyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		valueBytes, err := json.Marshal(entry.value)
+		if err != nil {
+			return nil, err
+		}
+
+		if e != m.order.Front() {
+			buf.WriteByte(',')
+		}
+
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		buf.Write(valueBytes)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// jsonObjectKey normalizes the JSON encoding of a map key into a quoted JSON string, since object
+// keys must always be strings (e.g. an int key marshals to 42, but must appear as "42").
+func jsonObjectKey(keyBytes []byte) ([]byte, error) {
+	if len(keyBytes) > 0 && keyBytes[0] == '"' {
+		return keyBytes, nil
+	}
+
+	return json.Marshal(string(keyBytes))
+}
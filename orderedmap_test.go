@@ -0,0 +1,249 @@
+package atlas
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedMapSet(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+
+	value, ok := m.Get("key1")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	t.Run("updates an existing key in place without reordering", func(t *testing.T) {
+		m.Set("key1", 11)
+
+		value, ok := m.Get("key1")
+		assert.True(t, ok)
+		assert.Equal(t, 11, value)
+
+		assert.Equal(t, []string{"key1", "key2"}, m.Keys())
+	})
+
+	t.Run("ensures no race condition", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			m.Set("go1", 1)
+		}()
+
+		go func() {
+			defer wg.Done()
+			m.Set("go2", 2)
+		}()
+
+		wg.Wait()
+
+		assert.True(t, m.Has("go1"))
+		assert.True(t, m.Has("go2"))
+	})
+}
+
+func TestOrderedMapHas(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	assert.False(t, m.Has("key"))
+
+	m.Set("key", 1)
+	assert.True(t, m.Has("key"))
+}
+
+func TestOrderedMapGet(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+
+	value, ok := m.Get("missing")
+	assert.False(t, ok)
+	assert.Empty(t, value)
+
+	m.Set("key", 42)
+	value, ok = m.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, 42, value)
+}
+
+func TestOrderedMapDelete(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+
+	// Nothing happens
+	m.Delete("key")
+
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+
+	m.Delete("key1")
+	assert.False(t, m.Has("key1"))
+	assert.Equal(t, []string{"key2"}, m.Keys())
+}
+
+func TestOrderedMapMoveToBack(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+	m.Set("key3", 3)
+
+	m.MoveToBack("key1")
+	assert.Equal(t, []string{"key2", "key3", "key1"}, m.Keys())
+
+	// Nothing happens for a missing key.
+	m.MoveToBack("missing")
+	assert.Equal(t, []string{"key2", "key3", "key1"}, m.Keys())
+}
+
+func TestOrderedMapKeys(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("key3", 3)
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+
+	assert.Equal(t, []string{"key3", "key1", "key2"}, m.Keys())
+}
+
+func TestOrderedMapValues(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("key3", 3)
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+
+	assert.Equal(t, []int{3, 1, 2}, m.Values())
+}
+
+func TestOrderedMapRange(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+	m.Set("key3", 3)
+
+	t.Run("iterates in insertion order", func(t *testing.T) {
+		var keys []string
+		m.Range(func(k string, v int) bool {
+			keys = append(keys, k)
+			return true
+		})
+
+		assert.Equal(t, []string{"key1", "key2", "key3"}, keys)
+	})
+
+	t.Run("stops early when fn returns false", func(t *testing.T) {
+		var keys []string
+		m.Range(func(k string, v int) bool {
+			keys = append(keys, k)
+			return k != "key2"
+		})
+
+		assert.Equal(t, []string{"key1", "key2"}, keys)
+	})
+}
+
+func TestOrderedMapGetPair(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+
+	key, value, ok := m.GetPair(1)
+	assert.True(t, ok)
+	assert.Equal(t, "key2", key)
+	assert.Equal(t, 2, value)
+
+	_, _, ok = m.GetPair(2)
+	assert.False(t, ok)
+
+	_, _, ok = m.GetPair(-1)
+	assert.False(t, ok)
+}
+
+func TestOrderedMapSize(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	assert.Equal(t, 0, m.Size())
+
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+	assert.Equal(t, 2, m.Size())
+}
+
+func TestOrderedMapToMap(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("key1", 1)
+	m.Set("key2", 2)
+
+	gotMap := m.ToMap()
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, gotMap)
+
+	gotMap["key3"] = 3
+	assert.False(t, m.Has("key3"))
+}
+
+func TestOrderedMapMarshalJSON(t *testing.T) {
+	t.Run("emits keys in insertion order", func(t *testing.T) {
+		m := NewOrderedMap[string, int]()
+		m.Set("key3", 3)
+		m.Set("key1", 1)
+		m.Set("key2", 2)
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, `{"key3":3,"key1":1,"key2":2}`, string(data))
+	})
+
+	t.Run("normalizes non-string keys into quoted JSON strings", func(t *testing.T) {
+		m := NewOrderedMap[int, string]()
+		m.Set(2, "two")
+		m.Set(1, "one")
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, `{"2":"two","1":"one"}`, string(data))
+	})
+}
+
+func BenchmarkOrderedMapSet(b *testing.B) {
+	m := NewOrderedMap[string, int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+}
+
+func BenchmarkFrozenMapSet(b *testing.B) {
+	m := NewFrozenMap[string, int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.Set(strconv.Itoa(i), i)
+	}
+}
+
+func BenchmarkOrderedMapGet(b *testing.B) {
+	m := NewOrderedMap[string, int]()
+	for i := 0; i < 1000; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(strconv.Itoa(i % 1000))
+	}
+}
+
+func BenchmarkFrozenMapGet(b *testing.B) {
+	m := NewFrozenMap[string, int]()
+	for i := 0; i < 1000; i++ {
+		_ = m.Set(strconv.Itoa(i), i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(strconv.Itoa(i % 1000))
+	}
+}
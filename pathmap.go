@@ -0,0 +1,242 @@
+package atlas
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrPathBlocked is returned by SetByPath when an intermediate segment in the path already holds
+// a non-map value, so a nested map can't be created at that point.
+var ErrPathBlocked = errors.New("path is blocked by a non-map value")
+
+type pathMap struct {
+	mu sync.RWMutex
+	mp map[string]any
+}
+
+// NewPathMap returns a new map that supports dot-path navigation across nested maps and slices,
+// inspired by nmap.Data and Elastic's MapStr.
+func NewPathMap() *pathMap {
+	return &pathMap{mp: make(map[string]any)}
+}
+
+// splitPath splits a dot-separated path into its segments, allowing "\." to escape a literal dot
+// within a segment.
+func splitPath(path string) []string {
+	var segments []string
+
+	var current strings.Builder
+	escaped := false
+
+	for _, r := range path {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '.':
+			segments = append(segments, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	segments = append(segments, current.String())
+
+	return segments
+}
+
+// navigate walks v following segments, descending into maps and slices. It returns the final
+// value and whether the full path was found.
+func navigate(v any, segments []string) (any, bool) {
+	current := v
+
+	for _, segment := range segments {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []any:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, false
+			}
+			current = node[idx]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// Get returns the value at the given dot-path, also returning whether it was found.
+func (m *pathMap) Get(path string) (any, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return navigate(m.mp, splitPath(path))
+}
+
+// Has checks whether a value exists at the given dot-path.
+func (m *pathMap) Has(path string) bool {
+	_, ok := m.Get(path)
+	return ok
+}
+
+// Delete removes the value at the given dot-path. It's a no-op if the path doesn't exist.
+func (m *pathMap) Delete(path string) {
+	segments := splitPath(path)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	parent, ok := navigate(m.mp, segments[:len(segments)-1])
+	if !ok {
+		return
+	}
+
+	node, ok := parent.(map[string]any)
+	if !ok {
+		return
+	}
+
+	delete(node, segments[len(segments)-1])
+}
+
+// SetByPath sets the value at the given dot-path, auto-creating intermediate map[string]any
+// levels as needed. It returns ErrPathBlocked if a non-map intermediate value blocks descent.
+func (m *pathMap) SetByPath(path string, v any) error {
+	segments := splitPath(path)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node := m.mp
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := node[segment]
+		if !ok {
+			nextNode := make(map[string]any)
+			node[segment] = nextNode
+			node = nextNode
+			continue
+		}
+
+		nextNode, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: segment %q", ErrPathBlocked, segment)
+		}
+		node = nextNode
+	}
+
+	node[segments[len(segments)-1]] = v
+
+	return nil
+}
+
+// Int returns the int value at the given dot-path, or the zero value if missing or of a
+// different type.
+func (m *pathMap) Int(path string) int {
+	value, ok := m.Get(path)
+	if !ok {
+		return 0
+	}
+
+	i, ok := value.(int)
+	if !ok {
+		return 0
+	}
+
+	return i
+}
+
+// Int64 returns the int64 value at the given dot-path, or the zero value if missing or of a
+// different type.
+func (m *pathMap) Int64(path string) int64 {
+	value, ok := m.Get(path)
+	if !ok {
+		return 0
+	}
+
+	i, ok := value.(int64)
+	if !ok {
+		return 0
+	}
+
+	return i
+}
+
+// Str returns the string value at the given dot-path, or the zero value if missing or of a
+// different type.
+func (m *pathMap) Str(path string) string {
+	value, ok := m.Get(path)
+	if !ok {
+		return ""
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return ""
+	}
+
+	return s
+}
+
+// Bool returns the bool value at the given dot-path, or the zero value if missing or of a
+// different type.
+func (m *pathMap) Bool(path string) bool {
+	value, ok := m.Get(path)
+	if !ok {
+		return false
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		return false
+	}
+
+	return b
+}
+
+// StringMap returns the map[string]string value at the given dot-path, converting a
+// map[string]any whose values are all strings. It returns nil if missing or of a different type.
+func (m *pathMap) StringMap(path string) map[string]string {
+	value, ok := m.Get(path)
+	if !ok {
+		return nil
+	}
+
+	switch node := value.(type) {
+	case map[string]string:
+		return node
+	case map[string]any:
+		out := make(map[string]string, len(node))
+		for k, v := range node {
+			s, ok := v.(string)
+			if !ok {
+				return nil
+			}
+			out[k] = s
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// Default returns the value at the given dot-path, or fallback if the path doesn't exist.
+func (m *pathMap) Default(path string, fallback any) any {
+	value, ok := m.Get(path)
+	if !ok {
+		return fallback
+	}
+
+	return value
+}
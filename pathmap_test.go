@@ -0,0 +1,190 @@
+package atlas
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathMapGet(t *testing.T) {
+	m := NewPathMap()
+	require.NoError(t, m.SetByPath("a.b.c", "value"))
+	require.NoError(t, m.SetByPath("items.0.name", "first"))
+	require.NoError(t, m.SetByPath("items.1.name", "second"))
+
+	t.Run("returns a nested value", func(t *testing.T) {
+		value, ok := m.Get("a.b.c")
+		assert.True(t, ok)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("returns a value from a nested map keyed by a numeric-looking segment", func(t *testing.T) {
+		value, ok := m.Get("items.1.name")
+		assert.True(t, ok)
+		assert.Equal(t, "second", value)
+	})
+
+	t.Run("returns a value from a real slice index", func(t *testing.T) {
+		sliceMap := NewPathMap()
+		sliceMap.mp["items"] = []any{
+			map[string]any{"name": "first"},
+			map[string]any{"name": "second"},
+		}
+
+		value, ok := sliceMap.Get("items.1.name")
+		assert.True(t, ok)
+		assert.Equal(t, "second", value)
+
+		value, ok = sliceMap.Get("items.2.name")
+		assert.False(t, ok)
+		assert.Nil(t, value)
+	})
+
+	t.Run("returns false for a missing intermediate segment", func(t *testing.T) {
+		value, ok := m.Get("a.missing.c")
+		assert.False(t, ok)
+		assert.Nil(t, value)
+	})
+
+	t.Run("returns false when an intermediate segment is the wrong type", func(t *testing.T) {
+		value, ok := m.Get("a.b.c.d")
+		assert.False(t, ok)
+		assert.Nil(t, value)
+	})
+
+	t.Run("supports escaped dots in a segment", func(t *testing.T) {
+		require.NoError(t, m.SetByPath(`host\.name`, "example.com"))
+
+		value, ok := m.Get(`host\.name`)
+		assert.True(t, ok)
+		assert.Equal(t, "example.com", value)
+	})
+}
+
+func TestPathMapHas(t *testing.T) {
+	m := NewPathMap()
+	assert.False(t, m.Has("a.b"))
+
+	require.NoError(t, m.SetByPath("a.b", "value"))
+	assert.True(t, m.Has("a.b"))
+}
+
+func TestPathMapSetByPath(t *testing.T) {
+	t.Run("auto-creates intermediate levels", func(t *testing.T) {
+		m := NewPathMap()
+		require.NoError(t, m.SetByPath("a.b.c", "value"))
+
+		value, ok := m.Get("a.b.c")
+		assert.True(t, ok)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("overwrites an existing value in place", func(t *testing.T) {
+		m := NewPathMap()
+		require.NoError(t, m.SetByPath("a.b", "first"))
+		require.NoError(t, m.SetByPath("a.b", "second"))
+
+		value, ok := m.Get("a.b")
+		assert.True(t, ok)
+		assert.Equal(t, "second", value)
+	})
+
+	t.Run("returns an error when a non-map intermediate value blocks descent", func(t *testing.T) {
+		m := NewPathMap()
+		require.NoError(t, m.SetByPath("a.b", "string-value"))
+
+		err := m.SetByPath("a.b.c", "value")
+		assert.ErrorIs(t, err, ErrPathBlocked)
+	})
+
+	t.Run("ensures no race condition", func(t *testing.T) {
+		m := NewPathMap()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			require.NoError(t, m.SetByPath("a.b", "value1"))
+		}()
+
+		go func() {
+			defer wg.Done()
+			require.NoError(t, m.SetByPath("c.d", "value2"))
+		}()
+
+		wg.Wait()
+
+		assert.True(t, m.Has("a.b"))
+		assert.True(t, m.Has("c.d"))
+	})
+}
+
+func TestPathMapDelete(t *testing.T) {
+	m := NewPathMap()
+
+	// Nothing happens
+	m.Delete("a.b")
+
+	require.NoError(t, m.SetByPath("a.b", "value"))
+	assert.True(t, m.Has("a.b"))
+
+	m.Delete("a.b")
+	assert.False(t, m.Has("a.b"))
+}
+
+func TestPathMapInt(t *testing.T) {
+	m := NewPathMap()
+	require.NoError(t, m.SetByPath("a.b", 42))
+	require.NoError(t, m.SetByPath("a.c", "not-an-int"))
+
+	assert.Equal(t, 42, m.Int("a.b"))
+	assert.Equal(t, 0, m.Int("a.c"))
+	assert.Equal(t, 0, m.Int("missing"))
+}
+
+func TestPathMapInt64(t *testing.T) {
+	m := NewPathMap()
+	require.NoError(t, m.SetByPath("a.b", int64(42)))
+
+	assert.Equal(t, int64(42), m.Int64("a.b"))
+	assert.Equal(t, int64(0), m.Int64("missing"))
+}
+
+func TestPathMapStr(t *testing.T) {
+	m := NewPathMap()
+	require.NoError(t, m.SetByPath("a.b", "value"))
+	require.NoError(t, m.SetByPath("a.c", 42))
+
+	assert.Equal(t, "value", m.Str("a.b"))
+	assert.Equal(t, "", m.Str("a.c"))
+	assert.Equal(t, "", m.Str("missing"))
+}
+
+func TestPathMapBool(t *testing.T) {
+	m := NewPathMap()
+	require.NoError(t, m.SetByPath("a.b", true))
+
+	assert.True(t, m.Bool("a.b"))
+	assert.False(t, m.Bool("missing"))
+}
+
+func TestPathMapStringMap(t *testing.T) {
+	m := NewPathMap()
+	require.NoError(t, m.SetByPath("a.b", map[string]any{"x": "1", "y": "2"}))
+	require.NoError(t, m.SetByPath("a.c", map[string]any{"x": 1}))
+
+	assert.Equal(t, map[string]string{"x": "1", "y": "2"}, m.StringMap("a.b"))
+	assert.Nil(t, m.StringMap("a.c"))
+	assert.Nil(t, m.StringMap("missing"))
+}
+
+func TestPathMapDefault(t *testing.T) {
+	m := NewPathMap()
+	require.NoError(t, m.SetByPath("a.b", "value"))
+
+	assert.Equal(t, "value", m.Default("a.b", "fallback"))
+	assert.Equal(t, "fallback", m.Default("missing", "fallback"))
+}